@@ -0,0 +1,154 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// XPackSqlAsyncDeleteService deletes a SQL query previously submitted
+// via XPackSqlAsyncQueryService, and cancels it if it is still running.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-search-api.html#sql-async-delete-api.
+type XPackSqlAsyncDeleteService struct {
+	client *Client
+
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	id string
+}
+
+// NewXPackSqlAsyncDeleteService creates a new XPackSqlAsyncDeleteService.
+func NewXPackSqlAsyncDeleteService(client *Client) *XPackSqlAsyncDeleteService {
+	return &XPackSqlAsyncDeleteService{
+		client: client,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *XPackSqlAsyncDeleteService) Pretty(pretty bool) *XPackSqlAsyncDeleteService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *XPackSqlAsyncDeleteService) Human(human bool) *XPackSqlAsyncDeleteService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *XPackSqlAsyncDeleteService) ErrorTrace(errorTrace bool) *XPackSqlAsyncDeleteService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *XPackSqlAsyncDeleteService) FilterPath(filterPath ...string) *XPackSqlAsyncDeleteService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackSqlAsyncDeleteService) Header(name string, value string) *XPackSqlAsyncDeleteService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *XPackSqlAsyncDeleteService) Headers(headers http.Header) *XPackSqlAsyncDeleteService {
+	s.headers = headers
+	return s
+}
+
+// ID specifies the id of the async SQL search to delete.
+func (s *XPackSqlAsyncDeleteService) ID(id string) *XPackSqlAsyncDeleteService {
+	s.id = id
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackSqlAsyncDeleteService) buildURL() (string, url.Values, error) {
+	// Build URL path
+	path := "/_sql/async/delete/" + url.PathEscape(s.id)
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackSqlAsyncDeleteService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "ID")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackSqlAsyncDeleteService) Do(ctx context.Context) (*XPackSqlAsyncDeleteResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "DELETE",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackSqlAsyncDeleteResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// XPackSqlAsyncDeleteResponse is the response of XPackSqlAsyncDeleteService.Do.
+type XPackSqlAsyncDeleteResponse struct {
+	Acknowledged bool `json:"acknowledged,omitempty"`
+}
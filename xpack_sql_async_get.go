@@ -0,0 +1,162 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// XPackSqlAsyncGetService retrieves the current results of a SQL query
+// previously submitted via XPackSqlAsyncQueryService, identified by its ID.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-search-api.html#sql-async.
+type XPackSqlAsyncGetService struct {
+	client *Client
+
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	id                       string
+	waitForCompletionTimeout string
+}
+
+// NewXPackSqlAsyncGetService creates a new XPackSqlAsyncGetService.
+func NewXPackSqlAsyncGetService(client *Client) *XPackSqlAsyncGetService {
+	return &XPackSqlAsyncGetService{
+		client: client,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *XPackSqlAsyncGetService) Pretty(pretty bool) *XPackSqlAsyncGetService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *XPackSqlAsyncGetService) Human(human bool) *XPackSqlAsyncGetService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *XPackSqlAsyncGetService) ErrorTrace(errorTrace bool) *XPackSqlAsyncGetService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *XPackSqlAsyncGetService) FilterPath(filterPath ...string) *XPackSqlAsyncGetService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackSqlAsyncGetService) Header(name string, value string) *XPackSqlAsyncGetService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *XPackSqlAsyncGetService) Headers(headers http.Header) *XPackSqlAsyncGetService {
+	s.headers = headers
+	return s
+}
+
+// ID specifies the id of the async SQL search to retrieve.
+func (s *XPackSqlAsyncGetService) ID(id string) *XPackSqlAsyncGetService {
+	s.id = id
+	return s
+}
+
+// WaitForCompletionTimeout specifies the duration the request waits for
+// the query to complete before returning the (possibly still partial)
+// result as-is.
+func (s *XPackSqlAsyncGetService) WaitForCompletionTimeout(timeout string) *XPackSqlAsyncGetService {
+	s.waitForCompletionTimeout = timeout
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackSqlAsyncGetService) buildURL() (string, url.Values, error) {
+	// Build URL path
+	path := "/_sql/async/" + url.PathEscape(s.id)
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if len(s.waitForCompletionTimeout) > 0 {
+		params.Set("wait_for_completion_timeout", s.waitForCompletionTimeout)
+	}
+	params.Set("format", "json")
+
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackSqlAsyncGetService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "ID")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackSqlAsyncGetService) Do(ctx context.Context) (*XPackSqlAsyncQueryResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "GET",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackSqlAsyncQueryResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// XPackSqlAsyncQueryService runs a SQL query asynchronously. If the
+// query does not complete within WaitForCompletionTimeout, a partial
+// (or, if KeepOnCompletion is set, always) result carrying an ID is
+// returned, which can be followed up on with XPackSqlAsyncGetService,
+// XPackSqlAsyncStatusService and XPackSqlAsyncDeleteService.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-search-api.html#sql-async.
+type XPackSqlAsyncQueryService struct {
+	client *Client
+
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	filterClauses           []Query
+	fetchSize               int
+	sql                     string
+	params                  []interface{}
+	requestTimeout          string
+	pageTimeout             string
+	timeZone                string
+	fieldMultiValueLeniency bool
+
+	waitForCompletionTimeout string
+	keepAlive                string
+	keepOnCompletion         *bool
+}
+
+// NewXPackSqlAsyncQueryService creates a new XPackSqlAsyncQueryService.
+func NewXPackSqlAsyncQueryService(client *Client) *XPackSqlAsyncQueryService {
+	return &XPackSqlAsyncQueryService{
+		client: client,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *XPackSqlAsyncQueryService) Pretty(pretty bool) *XPackSqlAsyncQueryService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *XPackSqlAsyncQueryService) Human(human bool) *XPackSqlAsyncQueryService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *XPackSqlAsyncQueryService) ErrorTrace(errorTrace bool) *XPackSqlAsyncQueryService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *XPackSqlAsyncQueryService) FilterPath(filterPath ...string) *XPackSqlAsyncQueryService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackSqlAsyncQueryService) Header(name string, value string) *XPackSqlAsyncQueryService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *XPackSqlAsyncQueryService) Headers(headers http.Header) *XPackSqlAsyncQueryService {
+	s.headers = headers
+	return s
+}
+
+// SQL specifies the sql of the request
+func (s *XPackSqlAsyncQueryService) SQL(sql string) *XPackSqlAsyncQueryService {
+	s.sql = sql
+	return s
+}
+
+// Params specifies the values bound to the `?` placeholders used in
+// the sql of the request.
+func (s *XPackSqlAsyncQueryService) Params(params ...interface{}) *XPackSqlAsyncQueryService {
+	s.params = params
+	return s
+}
+
+// Filter specifies the filter of the request
+func (s *XPackSqlAsyncQueryService) Filter(filters ...Query) *XPackSqlAsyncQueryService {
+	s.filterClauses = append(s.filterClauses, filters...)
+	return s
+}
+
+// FetchSize specifies the fetch_size of the request
+func (s *XPackSqlAsyncQueryService) FetchSize(size int) *XPackSqlAsyncQueryService {
+	s.fetchSize = size
+	return s
+}
+
+// RequestTimeout specifies the request_timeout of the request
+func (s *XPackSqlAsyncQueryService) RequestTimeout(timeout string) *XPackSqlAsyncQueryService {
+	s.requestTimeout = timeout
+	return s
+}
+
+// PageTimeout specifies the page_timeout of the request
+func (s *XPackSqlAsyncQueryService) PageTimeout(timeout string) *XPackSqlAsyncQueryService {
+	s.pageTimeout = timeout
+	return s
+}
+
+// TimeZone specifies the sql of the request
+func (s *XPackSqlAsyncQueryService) TimeZone(zone string) *XPackSqlAsyncQueryService {
+	s.timeZone = zone
+	return s
+}
+
+// SetFieldMultiValueLeniency specifies the field_multi_value_leniency of the request
+func (s *XPackSqlAsyncQueryService) SetFieldMultiValueLeniency(leniency bool) *XPackSqlAsyncQueryService {
+	s.fieldMultiValueLeniency = leniency
+	return s
+}
+
+// WaitForCompletionTimeout specifies the duration the request waits for
+// the query to complete before returning a partial result with an ID
+// that can be followed up on via XPackSqlAsyncGetService.
+func (s *XPackSqlAsyncQueryService) WaitForCompletionTimeout(timeout string) *XPackSqlAsyncQueryService {
+	s.waitForCompletionTimeout = timeout
+	return s
+}
+
+// KeepAlive specifies how long Elasticsearch keeps the search and its
+// results available after the query has completed, e.g. "5d".
+func (s *XPackSqlAsyncQueryService) KeepAlive(keepAlive string) *XPackSqlAsyncQueryService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// KeepOnCompletion specifies whether Elasticsearch should store the
+// results for later retrieval even if the query completes within
+// WaitForCompletionTimeout.
+func (s *XPackSqlAsyncQueryService) KeepOnCompletion(keep bool) *XPackSqlAsyncQueryService {
+	s.keepOnCompletion = &keep
+	return s
+}
+
+// Source allows the user to set the request body manually without using
+// any of the structs and interfaces in Elastic.
+func (s *XPackSqlAsyncQueryService) Source() (interface{}, error) {
+	if len(s.sql) == 0 {
+		return nil, errors.New("query must be not empty")
+	}
+	source := make(map[string]interface{})
+	source["query"] = s.sql
+	if len(s.params) > 0 {
+		source["params"] = s.params
+	}
+	if s.fetchSize > 0 {
+		source["fetch_size"] = s.fetchSize
+	}
+	if len(s.pageTimeout) > 0 {
+		source["page_timeout"] = s.pageTimeout
+	}
+	if len(s.requestTimeout) > 0 {
+		source["request_timeout"] = s.requestTimeout
+	}
+	if len(s.timeZone) > 0 {
+		source["time_zone"] = s.timeZone
+	}
+	if s.fieldMultiValueLeniency {
+		source["field_multi_value_leniency"] = s.fieldMultiValueLeniency
+	}
+	if len(s.waitForCompletionTimeout) > 0 {
+		source["wait_for_completion_timeout"] = s.waitForCompletionTimeout
+	}
+	if len(s.keepAlive) > 0 {
+		source["keep_alive"] = s.keepAlive
+	}
+	if v := s.keepOnCompletion; v != nil {
+		source["keep_on_completion"] = *v
+	}
+
+	// filter
+	if len(s.filterClauses) == 1 {
+		src, err := s.filterClauses[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		source["filter"] = src
+	} else if len(s.filterClauses) > 1 {
+		var clauses []interface{}
+		for _, subQuery := range s.filterClauses {
+			src, err := subQuery.Source()
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, src)
+		}
+		source["filter"] = clauses
+	}
+
+	return source, nil
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackSqlAsyncQueryService) buildURL() (string, url.Values, error) {
+	// Build URL path
+	path := "/_sql"
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	params.Set("format", "json")
+
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackSqlAsyncQueryService) Validate() error {
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackSqlAsyncQueryService) Do(ctx context.Context) (*XPackSqlAsyncQueryResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get body for request
+	body, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "POST",
+		Path:    path,
+		Params:  params,
+		Body:    body,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackSqlAsyncQueryResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// XPackSqlAsyncQueryResponse is the response of XPackSqlAsyncQueryService.Do.
+type XPackSqlAsyncQueryResponse struct {
+	ID        string          `json:"id,omitempty"`
+	IsPartial bool            `json:"is_partial,omitempty"`
+	IsRunning bool            `json:"is_running,omitempty"`
+	Columns   []*Column       `json:"columns,omitempty"`
+	Rows      [][]interface{} `json:"rows,omitempty"`
+	Cursor    string          `json:"cursor,omitempty"`
+}
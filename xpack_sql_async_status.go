@@ -0,0 +1,160 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// XPackSqlAsyncStatusService reports the status of a SQL query
+// previously submitted via XPackSqlAsyncQueryService, without fetching
+// its results.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-search-api.html#sql-async-status-api.
+type XPackSqlAsyncStatusService struct {
+	client *Client
+
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	id string
+}
+
+// NewXPackSqlAsyncStatusService creates a new XPackSqlAsyncStatusService.
+func NewXPackSqlAsyncStatusService(client *Client) *XPackSqlAsyncStatusService {
+	return &XPackSqlAsyncStatusService{
+		client: client,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *XPackSqlAsyncStatusService) Pretty(pretty bool) *XPackSqlAsyncStatusService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *XPackSqlAsyncStatusService) Human(human bool) *XPackSqlAsyncStatusService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *XPackSqlAsyncStatusService) ErrorTrace(errorTrace bool) *XPackSqlAsyncStatusService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *XPackSqlAsyncStatusService) FilterPath(filterPath ...string) *XPackSqlAsyncStatusService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *XPackSqlAsyncStatusService) Header(name string, value string) *XPackSqlAsyncStatusService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *XPackSqlAsyncStatusService) Headers(headers http.Header) *XPackSqlAsyncStatusService {
+	s.headers = headers
+	return s
+}
+
+// ID specifies the id of the async SQL search to report on.
+func (s *XPackSqlAsyncStatusService) ID(id string) *XPackSqlAsyncStatusService {
+	s.id = id
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *XPackSqlAsyncStatusService) buildURL() (string, url.Values, error) {
+	// Build URL path
+	path := "/_sql/async/status/" + url.PathEscape(s.id)
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *XPackSqlAsyncStatusService) Validate() error {
+	var invalid []string
+	if s.id == "" {
+		invalid = append(invalid, "ID")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *XPackSqlAsyncStatusService) Do(ctx context.Context) (*XPackSqlAsyncStatusResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "GET",
+		Path:    path,
+		Params:  params,
+		Headers: s.headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(XPackSqlAsyncStatusResponse)
+	if err := json.Unmarshal(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// XPackSqlAsyncStatusResponse is the response of XPackSqlAsyncStatusService.Do.
+type XPackSqlAsyncStatusResponse struct {
+	ID                     string `json:"id,omitempty"`
+	IsPartial              bool   `json:"is_partial,omitempty"`
+	IsRunning              bool   `json:"is_running,omitempty"`
+	StartTimeInMillis      int64  `json:"start_time_in_millis,omitempty"`
+	ExpirationTimeInMillis int64  `json:"expiration_time_in_millis,omitempty"`
+	CompletionStatus       int    `json:"completion_status,omitempty"`
+}
@@ -0,0 +1,124 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "testing"
+
+func TestXPackSqlAsyncQueryServiceBuildURL(t *testing.T) {
+	svc := NewXPackSqlAsyncQueryService(nil).SQL("SELECT 1")
+
+	path, params, err := svc.buildURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/_sql" {
+		t.Fatalf("unexpected path: %s", path)
+	}
+	if got := params.Get("format"); got != "json" {
+		t.Fatalf("expected format=json, got %q", got)
+	}
+}
+
+func TestXPackSqlAsyncGetServiceBuildURL(t *testing.T) {
+	tests := []struct {
+		name                     string
+		id                       string
+		waitForCompletionTimeout string
+		wantPath                 string
+		wantTimeoutParam         string
+		wantTimeoutSet           bool
+	}{
+		{
+			name:     "simple id, no timeout",
+			id:       "FmR0bE1",
+			wantPath: "/_sql/async/FmR0bE1",
+		},
+		{
+			name:     "id requiring path escaping",
+			id:       "abc/def ghi",
+			wantPath: "/_sql/async/abc%2Fdef%20ghi",
+		},
+		{
+			name:                     "with wait_for_completion_timeout",
+			id:                       "FmR0bE1",
+			waitForCompletionTimeout: "30s",
+			wantPath:                 "/_sql/async/FmR0bE1",
+			wantTimeoutParam:         "30s",
+			wantTimeoutSet:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewXPackSqlAsyncGetService(nil).ID(tt.id)
+			if tt.waitForCompletionTimeout != "" {
+				svc = svc.WaitForCompletionTimeout(tt.waitForCompletionTimeout)
+			}
+			path, params, err := svc.buildURL()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if path != tt.wantPath {
+				t.Fatalf("path: got %q, want %q", path, tt.wantPath)
+			}
+			_, set := params["wait_for_completion_timeout"]
+			if set != tt.wantTimeoutSet {
+				t.Fatalf("wait_for_completion_timeout present: got %v, want %v", set, tt.wantTimeoutSet)
+			}
+			if tt.wantTimeoutSet {
+				if got := params.Get("wait_for_completion_timeout"); got != tt.wantTimeoutParam {
+					t.Fatalf("wait_for_completion_timeout: got %q, want %q", got, tt.wantTimeoutParam)
+				}
+			}
+		})
+	}
+}
+
+func TestXPackSqlAsyncGetServiceValidate(t *testing.T) {
+	svc := NewXPackSqlAsyncGetService(nil)
+	if err := svc.Validate(); err == nil {
+		t.Fatal("expected an error when ID is not set")
+	}
+	svc.ID("FmR0bE1")
+	if err := svc.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestXPackSqlAsyncStatusServiceBuildURL(t *testing.T) {
+	svc := NewXPackSqlAsyncStatusService(nil).ID("abc/def")
+	path, _, err := svc.buildURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "/_sql/async/status/abc%2Fdef"; path != want {
+		t.Fatalf("path: got %q, want %q", path, want)
+	}
+}
+
+func TestXPackSqlAsyncStatusServiceValidate(t *testing.T) {
+	svc := NewXPackSqlAsyncStatusService(nil)
+	if err := svc.Validate(); err == nil {
+		t.Fatal("expected an error when ID is not set")
+	}
+}
+
+func TestXPackSqlAsyncDeleteServiceBuildURL(t *testing.T) {
+	svc := NewXPackSqlAsyncDeleteService(nil).ID("abc/def")
+	path, _, err := svc.buildURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "/_sql/async/delete/abc%2Fdef"; path != want {
+		t.Fatalf("path: got %q, want %q", path, want)
+	}
+}
+
+func TestXPackSqlAsyncDeleteServiceValidate(t *testing.T) {
+	svc := NewXPackSqlAsyncDeleteService(nil)
+	if err := svc.Validate(); err == nil {
+		t.Fatal("expected an error when ID is not set")
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+func init() {
+	sql.Register("elastic-sql", &sqlDriver{})
+}
+
+// sqlDriver implements database/sql/driver.Driver on top of
+// XPackSqlQueryService, so Elasticsearch SQL can be used through Go's
+// database/sql package, e.g.
+//
+//   db, err := sql.Open("elastic-sql", "http://localhost:9200?fetch_size=1000&time_zone=UTC")
+//   rows, err := db.QueryContext(ctx, "SELECT * FROM my_index WHERE ? = true", true)
+type sqlDriver struct{}
+
+// Open implements driver.Driver.
+func (d *sqlDriver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+// OpenConnector implements driver.DriverContext.
+func (d *sqlDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := parseSqlDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConnector{driver: d, cfg: cfg}, nil
+}
+
+// sqlDSN holds the connection parameters parsed out of the DSN passed
+// to sql.Open("elastic-sql", dsn).
+type sqlDSN struct {
+	url       string
+	fetchSize int
+	timeZone  string
+}
+
+// parseSqlDSN parses a DSN of the form
+// "http://host:9200?fetch_size=1000&time_zone=UTC" into the URL used to
+// create the underlying Client and the default FetchSize/TimeZone
+// applied to every query issued through the connection.
+func parseSqlDSN(dsn string) (*sqlDSN, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &sqlDSN{}
+	q := u.Query()
+	if v := q.Get("fetch_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("elastic: invalid fetch_size %q in DSN: %w", v, err)
+		}
+		cfg.fetchSize = n
+	}
+	cfg.timeZone = q.Get("time_zone")
+	u.RawQuery = ""
+	cfg.url = u.String()
+	return cfg, nil
+}
+
+// sqlConnector implements driver.Connector. Since Elasticsearch's SQL
+// API is stateless HTTP, all driver.Conns obtained from Connect share a
+// single underlying Client instead of each starting their own
+// sniffer/healthcheck goroutines; the Client is only stopped once the
+// last of those Conns is closed.
+type sqlConnector struct {
+	driver *sqlDriver
+	cfg    *sqlDSN
+
+	mu     sync.Mutex
+	client *Client
+	refs   int
+}
+
+// Connect implements driver.Connector.
+func (c *sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client == nil {
+		client, err := NewClient(SetURL(c.cfg.url))
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
+	c.refs++
+	return &sqlConn{client: c.client, cfg: c.cfg, connector: c}, nil
+}
+
+// Driver implements driver.Connector.
+func (c *sqlConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// release drops one of the Conns sharing c.client, stopping the Client
+// once the last Conn has been closed.
+func (c *sqlConnector) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refs--
+	if c.refs <= 0 {
+		if c.client != nil {
+			c.client.Stop()
+			c.client = nil
+		}
+		c.refs = 0
+	}
+}
+
+// sqlConn implements driver.Conn and driver.Pinger. Elasticsearch's SQL
+// API is stateless HTTP, so a sqlConn simply wraps the Client shared by
+// its sqlConnector.
+type sqlConn struct {
+	client    *Client
+	cfg       *sqlDSN
+	connector *sqlConnector
+}
+
+// Prepare implements driver.Conn.
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqlStmt{conn: c, query: query}, nil
+}
+
+// Close implements driver.Conn, releasing this Conn's reference to the
+// shared Client and stopping it (and its sniffer/healthcheck
+// goroutines) once no Conn is using it anymore.
+func (c *sqlConn) Close() error {
+	c.connector.release()
+	return nil
+}
+
+// Begin implements driver.Conn. Elasticsearch SQL has no notion of
+// transactions.
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("elastic: transactions are not supported")
+}
+
+// Ping implements driver.Pinger by running a trivial SELECT 1 through
+// XPackSqlQueryService.
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if _, err := NewXPackSqlQueryService(c.client).SQL("SELECT 1").Do(ctx); err != nil {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+// sqlStmt implements driver.Stmt and driver.StmtQueryContext.
+type sqlStmt struct {
+	conn  *sqlConn
+	query string
+}
+
+// Close implements driver.Stmt.
+func (s *sqlStmt) Close() error {
+	return nil
+}
+
+// NumInput implements driver.Stmt. The number of `?` placeholders is
+// not known ahead of time, so database/sql is told not to sanity-check it.
+func (s *sqlStmt) NumInput() int {
+	return -1
+}
+
+// Exec implements driver.Stmt. Elasticsearch SQL is read-only.
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("elastic: Exec is not supported, Elasticsearch SQL is read-only")
+}
+
+// Query implements driver.Stmt.
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.QueryContext(context.Background(), named)
+}
+
+// namedValuesToParams reorders a driver.NamedValue slice, as received
+// by QueryContext, into a positional []interface{} suitable for
+// XPackSqlQueryService.Params, based on each value's 1-based Ordinal.
+func namedValuesToParams(args []driver.NamedValue) []interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+	params := make([]interface{}, len(args))
+	for _, a := range args {
+		params[a.Ordinal-1] = a.Value
+	}
+	return params
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	svc := NewXPackSqlQueryService(s.conn.client).SQL(s.query)
+	if s.conn.cfg.fetchSize > 0 {
+		svc = svc.FetchSize(s.conn.cfg.fetchSize)
+	}
+	if s.conn.cfg.timeZone != "" {
+		svc = svc.TimeZone(s.conn.cfg.timeZone)
+	}
+	if params := namedValuesToParams(args); params != nil {
+		svc = svc.Params(params...)
+	}
+	resp, err := svc.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newSqlRows(s.conn.client, resp), nil
+}
+
+// sqlRows implements driver.Rows and driver.RowsColumnTypeDatabaseTypeName,
+// paging through the result set via the cursor as Next exhausts each page.
+type sqlRows struct {
+	client  *Client
+	columns []*Column
+	rows    [][]interface{}
+	pos     int
+	cursor  string
+	closed  bool
+
+	// fetchPage requests the page for cursor. It is a field, rather
+	// than a direct call to XPackSqlQueryService, so tests can stub out
+	// the network round trip.
+	fetchPage func(cursor string) (*XPackSqlQueryResponse, error)
+}
+
+func newSqlRows(client *Client, resp *XPackSqlQueryResponse) *sqlRows {
+	r := &sqlRows{
+		client:  client,
+		columns: resp.Columns,
+		rows:    resp.Rows,
+		cursor:  resp.Cursor,
+	}
+	r.fetchPage = func(cursor string) (*XPackSqlQueryResponse, error) {
+		return NewXPackSqlQueryService(r.client).Cursor(cursor).Do(context.Background())
+	}
+	return r
+}
+
+// Columns implements driver.Rows.
+func (r *sqlRows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName,
+// returning the Elasticsearch SQL type name verbatim, as lowercased by
+// Elasticsearch, e.g. "keyword" or "long".
+func (r *sqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columns[index].Type
+}
+
+// Close implements driver.Rows, releasing the server-side cursor via
+// POST /_sql/close if one is still open.
+func (r *sqlRows) Close() error {
+	if r.closed || r.cursor == "" {
+		r.closed = true
+		return nil
+	}
+	r.closed = true
+	_, err := r.client.PerformRequest(context.Background(), PerformRequestOptions{
+		Method: "POST",
+		Path:   "/_sql/close",
+		Body:   map[string]interface{}{"cursor": r.cursor},
+	})
+	return err
+}
+
+// Next implements driver.Rows, transparently following the cursor to
+// fetch the next page once the current one is exhausted.
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		if r.cursor == "" {
+			return io.EOF
+		}
+		resp, err := r.fetchPage(r.cursor)
+		if err != nil {
+			return err
+		}
+		r.rows = resp.Rows
+		r.cursor = resp.Cursor
+		r.pos = 0
+		if len(r.rows) == 0 {
+			return io.EOF
+		}
+	}
+	row := r.rows[r.pos]
+	r.pos++
+	for i, raw := range row {
+		v, err := convertColumnValue(r.columns[i], raw)
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	return nil
+}
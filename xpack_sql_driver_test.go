@@ -0,0 +1,174 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+func TestParseSqlDSN(t *testing.T) {
+	tests := []struct {
+		name          string
+		dsn           string
+		wantURL       string
+		wantFetchSize int
+		wantTimeZone  string
+		wantErr       bool
+	}{
+		{
+			name:    "no query parameters",
+			dsn:     "http://localhost:9200",
+			wantURL: "http://localhost:9200",
+		},
+		{
+			name:          "fetch_size and time_zone",
+			dsn:           "http://localhost:9200?fetch_size=1000&time_zone=UTC",
+			wantURL:       "http://localhost:9200",
+			wantFetchSize: 1000,
+			wantTimeZone:  "UTC",
+		},
+		{
+			name:    "invalid fetch_size",
+			dsn:     "http://localhost:9200?fetch_size=not-a-number",
+			wantErr: true,
+		},
+		{
+			name:    "invalid DSN",
+			dsn:     "://bad-url",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseSqlDSN(tt.dsn)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.url != tt.wantURL {
+				t.Fatalf("url: got %q, want %q", cfg.url, tt.wantURL)
+			}
+			if cfg.fetchSize != tt.wantFetchSize {
+				t.Fatalf("fetchSize: got %d, want %d", cfg.fetchSize, tt.wantFetchSize)
+			}
+			if cfg.timeZone != tt.wantTimeZone {
+				t.Fatalf("timeZone: got %q, want %q", cfg.timeZone, tt.wantTimeZone)
+			}
+		})
+	}
+}
+
+func TestNamedValuesToParams(t *testing.T) {
+	if got := namedValuesToParams(nil); got != nil {
+		t.Fatalf("expected nil for no args, got %v", got)
+	}
+
+	args := []driver.NamedValue{
+		{Ordinal: 2, Value: "b"},
+		{Ordinal: 1, Value: "a"},
+	}
+	got := namedValuesToParams(args)
+	want := []interface{}{"a", "b"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSqlRowsNextSinglePage(t *testing.T) {
+	r := &sqlRows{
+		columns: []*Column{{Name: "id", Type: "long"}},
+		rows:    [][]interface{}{{float64(1)}, {float64(2)}},
+		fetchPage: func(cursor string) (*XPackSqlQueryResponse, error) {
+			t.Fatal("fetchPage should not be called when there is no cursor")
+			return nil, nil
+		},
+	}
+
+	dest := make([]driver.Value, 1)
+
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest[0] != int64(1) {
+		t.Fatalf("got %v, want 1", dest[0])
+	}
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest[0] != int64(2) {
+		t.Fatalf("got %v, want 2", dest[0])
+	}
+	if err := r.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestSqlRowsNextMultiPageViaCursor(t *testing.T) {
+	fetched := false
+	r := &sqlRows{
+		columns: []*Column{{Name: "id", Type: "long"}},
+		rows:    [][]interface{}{{float64(1)}},
+		cursor:  "page-2",
+		fetchPage: func(cursor string) (*XPackSqlQueryResponse, error) {
+			if cursor != "page-2" {
+				t.Fatalf("unexpected cursor: %q", cursor)
+			}
+			fetched = true
+			return &XPackSqlQueryResponse{
+				Rows:   [][]interface{}{{float64(2)}},
+				Cursor: "",
+			}, nil
+		},
+	}
+
+	dest := make([]driver.Value, 1)
+
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest[0] != int64(1) {
+		t.Fatalf("got %v, want 1", dest[0])
+	}
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fetched {
+		t.Fatal("expected fetchPage to have been called")
+	}
+	if dest[0] != int64(2) {
+		t.Fatalf("got %v, want 2", dest[0])
+	}
+	if err := r.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestSqlRowsNextEmptyFinalPage(t *testing.T) {
+	r := &sqlRows{
+		columns: []*Column{{Name: "id", Type: "long"}},
+		rows:    [][]interface{}{{float64(1)}},
+		cursor:  "page-2",
+		fetchPage: func(cursor string) (*XPackSqlQueryResponse, error) {
+			return &XPackSqlQueryResponse{Rows: nil, Cursor: ""}, nil
+		},
+	}
+
+	dest := make([]driver.Value, 1)
+
+	if err := r.Next(dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Next(dest); err != io.EOF {
+		t.Fatalf("expected io.EOF for an empty final page, got %v", err)
+	}
+}
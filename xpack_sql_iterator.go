@@ -0,0 +1,125 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/http"
+)
+
+// Iterate returns an Iterator that transparently pages through the
+// result set of the query, issuing a new /_sql request with the
+// previous response's cursor for each page. Callers must call Close
+// once they are done with the Iterator, whether or not it was fully
+// exhausted, to release the server-side cursor.
+func (s *XPackSqlQueryService) Iterate(ctx context.Context) (*Iterator, error) {
+	resp, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Iterator{
+		client:     s.client,
+		headers:    s.headers,
+		filterPath: s.filterPath,
+		columns:    resp.Columns,
+		rows:       resp.Rows,
+		cursor:     resp.Cursor,
+	}, nil
+}
+
+// Iterator follows the cursor of a XPackSqlQueryResponse, fetching
+// further pages from Elasticsearch on demand, similar to database/sql's
+// Rows.
+type Iterator struct {
+	client     *Client
+	headers    http.Header
+	filterPath []string
+	columns    []*Column
+	rows       [][]interface{}
+	pos        int
+	cursor     string
+	closed     bool
+	err        error
+}
+
+// Columns returns the column metadata of the result set.
+func (it *Iterator) Columns() []*Column {
+	return it.columns
+}
+
+// Next advances the Iterator to the next row, transparently fetching
+// the next page via the cursor when the current page is exhausted. It
+// returns false once there are no more rows or an error occurred; use
+// Err to distinguish between the two.
+func (it *Iterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos < len(it.rows) {
+		it.pos++
+		return true
+	}
+	if it.cursor == "" {
+		return false
+	}
+	if err := it.fetchNextPage(ctx); err != nil {
+		it.err = err
+		return false
+	}
+	if len(it.rows) == 0 {
+		return false
+	}
+	it.pos = 1
+	return true
+}
+
+// fetchNextPage requests the next page of the result set using the
+// current cursor and replaces the in-memory page and cursor with the
+// response. It carries over the Headers and FilterPath of the original
+// XPackSqlQueryService, so a caller-supplied FilterPath keeps applying
+// to every page, not just the first.
+func (it *Iterator) fetchNextPage(ctx context.Context) error {
+	resp, err := NewXPackSqlQueryService(it.client).
+		Cursor(it.cursor).
+		Headers(it.headers).
+		FilterPath(it.filterPath...).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	it.rows = resp.Rows
+	it.cursor = resp.Cursor
+	it.pos = 0
+	return nil
+}
+
+// Row returns the current row. It must only be called after a call to
+// Next returned true.
+func (it *Iterator) Row() *Row {
+	return NewRow(it.columns, it.rows[it.pos-1])
+}
+
+// Err returns the error, if any, that caused Next to return false.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close releases the server-side cursor, if one is still open. It is
+// safe to call Close multiple times and on an Iterator that was fully
+// exhausted.
+func (it *Iterator) Close(ctx context.Context) error {
+	if it.closed || it.cursor == "" {
+		it.closed = true
+		return nil
+	}
+	it.closed = true
+	_, err := it.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "POST",
+		Path:    "/_sql/close",
+		Body:    map[string]interface{}{"cursor": it.cursor},
+		Headers: it.headers,
+	})
+	return err
+}
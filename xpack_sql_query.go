@@ -29,12 +29,28 @@ type XPackSqlQueryService struct {
 	fetchSize               int
 	cursor                  string
 	sql                     string
+	params                  []interface{}
 	requestTimeout          string
 	pageTimeout             string
 	timeZone                string
+	format                  string
 	fieldMultiValueLeniency bool
 }
 
+// xpackSqlFormatAcceptHeaders maps the `format` query string parameter
+// accepted by the `_sql` endpoint to the HTTP `Accept` header that
+// requests it.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-rest-format.html.
+var xpackSqlFormatAcceptHeaders = map[string]string{
+	"json":  "application/json",
+	"csv":   "text/csv",
+	"tsv":   "text/tab-separated-values",
+	"txt":   "text/plain",
+	"yaml":  "application/yaml",
+	"cbor":  "application/cbor",
+	"smile": "application/smile",
+}
+
 // NewXPackSqlQueryService creates a new XPackSqlQueryService.
 func NewXPackSqlQueryService(client *Client) *XPackSqlQueryService {
 	return &XPackSqlQueryService{
@@ -100,6 +116,13 @@ func (s *XPackSqlQueryService) Filter(filters ...Query) *XPackSqlQueryService {
 	return s
 }
 
+// Params specifies the values bound to the `?` placeholders used in
+// the sql of the request.
+func (s *XPackSqlQueryService) Params(params ...interface{}) *XPackSqlQueryService {
+	s.params = params
+	return s
+}
+
 // FetchSize specifies the fetch_size of the request
 func (s *XPackSqlQueryService) FetchSize(size int) *XPackSqlQueryService {
 	s.fetchSize = size
@@ -130,6 +153,16 @@ func (s *XPackSqlQueryService) SetFieldMultiValueLeniency(leniency bool) *XPackS
 	return s
 }
 
+// Format specifies the response format of the request, e.g. "json", "csv",
+// "tsv", "txt", "yaml", "cbor" or "smile". It defaults to "json" and is
+// sent by DoRaw both as the `format` query string parameter and the
+// `Accept` header. Do ignores it: it always requests JSON and
+// unmarshals the response as JSON, so use DoRaw for non-JSON formats.
+func (s *XPackSqlQueryService) Format(format string) *XPackSqlQueryService {
+	s.format = format
+	return s
+}
+
 // Source allows the user to set the request body manually without using
 // any of the structs and interfaces in Elastic.
 func (s *XPackSqlQueryService) Source() (interface{}, error) {
@@ -143,6 +176,9 @@ func (s *XPackSqlQueryService) Source() (interface{}, error) {
 		return nil, errors.New("query and cursor must be not both empty")
 	}
 	source["query"] = s.sql
+	if len(s.params) > 0 {
+		source["params"] = s.params
+	}
 	if s.fetchSize > 0 {
 		source["fetch_size"] = s.fetchSize
 	}
@@ -182,7 +218,7 @@ func (s *XPackSqlQueryService) Source() (interface{}, error) {
 }
 
 // buildURL builds the URL for the operation.
-func (s *XPackSqlQueryService) buildURL() (string, url.Values, error) {
+func (s *XPackSqlQueryService) buildURL(format string) (string, url.Values, error) {
 	// Build URL path
 	path := "/_sql"
 
@@ -200,19 +236,33 @@ func (s *XPackSqlQueryService) buildURL() (string, url.Values, error) {
 	if len(s.filterPath) > 0 {
 		params.Set("filter_path", strings.Join(s.filterPath, ","))
 	}
-
-	// Support json format only for now
-	params.Set("format", "json")
+	params.Set("format", format)
 
 	return path, params, nil
 }
 
+// headersWithAccept returns a copy of s.headers with the Accept header
+// set to match format, so Elasticsearch returns the requested
+// representation of the result set.
+func (s *XPackSqlQueryService) headersWithAccept(format string) http.Header {
+	headers := http.Header{}
+	for k, v := range s.headers {
+		headers[k] = v
+	}
+	if accept, found := xpackSqlFormatAcceptHeaders[format]; found {
+		headers.Set("Accept", accept)
+	}
+	return headers
+}
+
 // Validate checks if the operation is valid.
 func (s *XPackSqlQueryService) Validate() error {
 	return nil
 }
 
-// Do executes the operation.
+// Do executes the operation and parses the response as JSON into a
+// XPackSqlQueryResponse, regardless of any format set via Format. Use
+// DoRaw to retrieve another format.
 func (s *XPackSqlQueryService) Do(ctx context.Context) (*XPackSqlQueryResponse, error) {
 	// Check pre-conditions
 	if err := s.Validate(); err != nil {
@@ -220,7 +270,7 @@ func (s *XPackSqlQueryService) Do(ctx context.Context) (*XPackSqlQueryResponse,
 	}
 
 	// Get URL for request
-	path, params, err := s.buildURL()
+	path, params, err := s.buildURL("json")
 	if err != nil {
 		return nil, err
 	}
@@ -237,7 +287,7 @@ func (s *XPackSqlQueryService) Do(ctx context.Context) (*XPackSqlQueryResponse,
 		Path:    path,
 		Params:  params,
 		Body:    body,
-		Headers: s.headers,
+		Headers: s.headersWithAccept("json"),
 	})
 	if err != nil {
 		return nil, err
@@ -251,6 +301,53 @@ func (s *XPackSqlQueryService) Do(ctx context.Context) (*XPackSqlQueryResponse,
 	return ret, nil
 }
 
+// DoRaw executes the operation using the format set via Format (or
+// "json" if none was set) and returns the raw response body together
+// with its content type, without attempting to parse it. This is the
+// way to retrieve non-JSON formats such as csv, tsv, txt, yaml, cbor
+// or smile.
+func (s *XPackSqlQueryService) DoRaw(ctx context.Context) ([]byte, string, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	format := s.format
+	if format == "" {
+		format = "json"
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL(format)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Get body for request
+	body, err := s.Source()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:  "POST",
+		Path:    path,
+		Params:  params,
+		Body:    body,
+		Headers: s.headersWithAccept(format),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = xpackSqlFormatAcceptHeaders[format]
+	}
+	return res.Body, contentType, nil
+}
+
 // XPackSqlQueryResponse is the response of XPackSqlQueryService.Do.
 type XPackSqlQueryResponse struct {
 	Columns []*Column       `json:"columns,omitempty"`
@@ -0,0 +1,272 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Row represents a single row of a XPackSqlQueryResponse together with
+// the column metadata required to decode its values into Go types.
+// Use NewRow to create one from a Column slice and a matching value
+// slice, e.g. resp.Columns and an entry of resp.Rows.
+type Row struct {
+	columns []*Column
+	values  []interface{}
+}
+
+// NewRow creates a new Row from a set of columns and their values, as
+// returned by XPackSqlQueryService.Do in XPackSqlQueryResponse.Columns
+// and XPackSqlQueryResponse.Rows.
+func NewRow(columns []*Column, values []interface{}) *Row {
+	return &Row{columns: columns, values: values}
+}
+
+// Scan copies the values of the row into dest, in column order,
+// converting each one according to its declared Column.Type. The
+// number of destinations must match the number of columns.
+func (r *Row) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.values) {
+		return fmt.Errorf("elastic: Scan: expected %d destination(s), got %d", len(r.values), len(dest))
+	}
+	for i, raw := range r.values {
+		v, err := convertColumnValue(r.columns[i], raw)
+		if err != nil {
+			return fmt.Errorf("elastic: Scan: column %q: %w", r.columns[i].Name, err)
+		}
+		if err := assign(dest[i], v); err != nil {
+			return fmt.Errorf("elastic: Scan: column %q: %w", r.columns[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// Decode unmarshals the row into v, a pointer to a struct, matching
+// columns to fields by JSON tag or, falling back, by field name
+// (case-insensitively). Column values are converted according to
+// their declared Column.Type and assigned via reflection, so unlike a
+// JSON-marshal round trip, it also handles "double"/"float" columns
+// holding NaN or +/-Infinity.
+func (r *Row) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("elastic: Decode: v must be a non-nil pointer, got %T", v)
+	}
+	elem := rv.Elem()
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("elastic: Decode: v must point to a struct, got %T", v)
+	}
+	t := elem.Type()
+
+	for i, c := range r.columns {
+		cv, err := convertColumnValue(c, r.values[i])
+		if err != nil {
+			return fmt.Errorf("elastic: Decode: column %q: %w", c.Name, err)
+		}
+		if cv == nil {
+			continue
+		}
+		field, ok := findStructField(t, c.Name)
+		if !ok {
+			continue
+		}
+		fv := elem.FieldByIndex(field.Index)
+		if !fv.CanSet() {
+			continue
+		}
+		if err := setReflectValue(fv, cv); err != nil {
+			return fmt.Errorf("elastic: Decode: column %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// findStructField looks up the field of t matching name, preferring an
+// exact match of the field's `json` tag name, then falling back to a
+// case-insensitive match of the field name itself.
+func findStructField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tagName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tagName == name {
+			return f, true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// setReflectValue assigns the concrete value produced by
+// convertColumnValue to fv, converting between compatible numeric
+// kinds and handling time.Time and the generic interface{} escape
+// hatch.
+func setReflectValue(fv reflect.Value, v interface{}) error {
+	if fv.Type() == timeType {
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to time.Time", v)
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.Interface:
+		fv.Set(reflect.ValueOf(v))
+	case reflect.String:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to string", v)
+		}
+		fv.SetString(s)
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to bool", v)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(int64)
+		if !ok {
+			return fmt.Errorf("cannot assign %T to %s", v, fv.Kind())
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		switch n := v.(type) {
+		case float64:
+			fv.SetFloat(n)
+		case int64:
+			fv.SetFloat(float64(n))
+		default:
+			return fmt.Errorf("cannot assign %T to %s", v, fv.Kind())
+		}
+	default:
+		return fmt.Errorf("unsupported field kind %s", fv.Kind())
+	}
+	return nil
+}
+
+// assign stores v in the value pointed to by dest, supporting both the
+// concrete Go type produced by convertColumnValue and the generic
+// *interface{} escape hatch.
+func assign(dest interface{}, v interface{}) error {
+	switch d := dest.(type) {
+	case *interface{}:
+		*d = v
+		return nil
+	case *string:
+		s, ok := v.(string)
+		if !ok && v != nil {
+			return fmt.Errorf("cannot assign %T to *string", v)
+		}
+		*d = s
+		return nil
+	case *int64:
+		n, ok := v.(int64)
+		if !ok && v != nil {
+			return fmt.Errorf("cannot assign %T to *int64", v)
+		}
+		*d = n
+		return nil
+	case *float64:
+		n, ok := v.(float64)
+		if !ok && v != nil {
+			return fmt.Errorf("cannot assign %T to *float64", v)
+		}
+		*d = n
+		return nil
+	case *bool:
+		b, ok := v.(bool)
+		if !ok && v != nil {
+			return fmt.Errorf("cannot assign %T to *bool", v)
+		}
+		*d = b
+		return nil
+	case *time.Time:
+		t, ok := v.(time.Time)
+		if !ok && v != nil {
+			return fmt.Errorf("cannot assign %T to *time.Time", v)
+		}
+		*d = t
+		return nil
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+}
+
+// convertColumnValue converts a raw JSON-decoded value (as found in
+// XPackSqlQueryResponse.Rows) into its Go representation based on the
+// declared column type.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/sql-data-types.html.
+func convertColumnValue(c *Column, raw interface{}) (interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	switch c.Type {
+	case "long", "integer", "short", "byte":
+		switch n := raw.(type) {
+		case float64:
+			return int64(n), nil
+		case string:
+			var i int64
+			if _, err := fmt.Sscanf(n, "%d", &i); err != nil {
+				return nil, err
+			}
+			return i, nil
+		}
+		return nil, fmt.Errorf("cannot convert %T to int64", raw)
+	case "double", "float", "half_float", "scaled_float":
+		switch n := raw.(type) {
+		case float64:
+			return n, nil
+		case string:
+			// JSON cannot represent NaN or +/-Infinity as numbers, so
+			// Elasticsearch SQL renders them as these quoted strings.
+			switch n {
+			case "NaN":
+				return math.NaN(), nil
+			case "Infinity":
+				return math.Inf(1), nil
+			case "-Infinity":
+				return math.Inf(-1), nil
+			}
+		}
+		return nil, fmt.Errorf("cannot convert %T to float64", raw)
+	case "boolean":
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to bool", raw)
+		}
+		return b, nil
+	case "date", "datetime":
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to time.Time", raw)
+		}
+		// Elasticsearch SQL renders dates as ISO-8601 strings,
+		// carrying their own time zone/offset, e.g.
+		// "2020-01-02T03:04:05.000Z".
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t, err = time.Parse("2006-01-02T15:04:05.000Z", s)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	default:
+		return raw, nil
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConvertColumnValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  *Column
+		raw     interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "long from float64",
+			column: &Column{Name: "cnt", Type: "long"},
+			raw:    float64(42),
+			want:   int64(42),
+		},
+		{
+			name:   "integer from string",
+			column: &Column{Name: "cnt", Type: "integer"},
+			raw:    "42",
+			want:   int64(42),
+		},
+		{
+			name:   "double from float64",
+			column: &Column{Name: "val", Type: "double"},
+			raw:    float64(1.5),
+			want:   float64(1.5),
+		},
+		{
+			name:   "double NaN as quoted string",
+			column: &Column{Name: "val", Type: "double"},
+			raw:    "NaN",
+			want:   math.NaN(),
+		},
+		{
+			name:   "double Infinity as quoted string",
+			column: &Column{Name: "val", Type: "double"},
+			raw:    "Infinity",
+			want:   math.Inf(1),
+		},
+		{
+			name:   "double -Infinity as quoted string",
+			column: &Column{Name: "val", Type: "double"},
+			raw:    "-Infinity",
+			want:   math.Inf(-1),
+		},
+		{
+			name:    "double with unrecognized string",
+			column:  &Column{Name: "val", Type: "double"},
+			raw:     "not-a-number",
+			wantErr: true,
+		},
+		{
+			name:   "boolean",
+			column: &Column{Name: "ok", Type: "boolean"},
+			raw:    true,
+			want:   true,
+		},
+		{
+			name:    "boolean with wrong type",
+			column:  &Column{Name: "ok", Type: "boolean"},
+			raw:     "true",
+			wantErr: true,
+		},
+		{
+			name:   "date in RFC3339",
+			column: &Column{Name: "created", Type: "date"},
+			raw:    "2020-01-02T03:04:05Z",
+			want:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:   "date with millisecond fallback format",
+			column: &Column{Name: "created", Type: "datetime"},
+			raw:    "2020-01-02T03:04:05.000Z",
+			want:   time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "date with unparsable string",
+			column:  &Column{Name: "created", Type: "date"},
+			raw:     "not-a-date",
+			wantErr: true,
+		},
+		{
+			name:   "keyword passthrough",
+			column: &Column{Name: "name", Type: "keyword"},
+			raw:    "hello",
+			want:   "hello",
+		},
+		{
+			name:   "nil value",
+			column: &Column{Name: "name", Type: "keyword"},
+			raw:    nil,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertColumnValue(tt.column, tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none (value=%v)", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if f, ok := tt.want.(float64); ok && math.IsNaN(f) {
+				gf, ok := got.(float64)
+				if !ok || !math.IsNaN(gf) {
+					t.Fatalf("want NaN, got %v", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("want %v (%T), got %v (%T)", tt.want, tt.want, got, got)
+			}
+		})
+	}
+}
+
+func TestRowScan(t *testing.T) {
+	columns := []*Column{
+		{Name: "id", Type: "long"},
+		{Name: "name", Type: "keyword"},
+		{Name: "active", Type: "boolean"},
+	}
+	row := NewRow(columns, []interface{}{float64(7), "gopher", true})
+
+	var id int64
+	var name string
+	var active bool
+	if err := row.Scan(&id, &name, &active); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if id != 7 || name != "gopher" || !active {
+		t.Fatalf("unexpected scan result: id=%d name=%q active=%v", id, name, active)
+	}
+}
+
+func TestRowScanWrongDestinationCount(t *testing.T) {
+	columns := []*Column{{Name: "id", Type: "long"}}
+	row := NewRow(columns, []interface{}{float64(7)})
+
+	var id, extra int64
+	if err := row.Scan(&id, &extra); err == nil {
+		t.Fatal("expected an error for mismatched destination count")
+	}
+}
+
+func TestRowDecode(t *testing.T) {
+	columns := []*Column{
+		{Name: "id", Type: "long"},
+		{Name: "name", Type: "keyword"},
+	}
+	row := NewRow(columns, []interface{}{float64(7), "gopher"})
+
+	var dest struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := row.Decode(&dest); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if dest.ID != 7 || dest.Name != "gopher" {
+		t.Fatalf("unexpected decode result: %+v", dest)
+	}
+}
+
+func TestRowDecodeWithNaNDoubleColumn(t *testing.T) {
+	columns := []*Column{
+		{Name: "id", Type: "long"},
+		{Name: "score", Type: "double"},
+	}
+	row := NewRow(columns, []interface{}{float64(1), "NaN"})
+
+	var dest struct {
+		ID    int64   `json:"id"`
+		Score float64 `json:"score"`
+	}
+	if err := row.Decode(&dest); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if dest.ID != 1 || !math.IsNaN(dest.Score) {
+		t.Fatalf("unexpected decode result: %+v", dest)
+	}
+}
+
+func TestRowDecodeFieldNameFallback(t *testing.T) {
+	columns := []*Column{{Name: "fullname", Type: "keyword"}}
+	row := NewRow(columns, []interface{}{"gopher"})
+
+	var dest struct {
+		FullName string
+	}
+	if err := row.Decode(&dest); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if dest.FullName != "gopher" {
+		t.Fatalf("unexpected decode result: %+v", dest)
+	}
+}
@@ -6,11 +6,13 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // XPackSqlTranslateService translate sql into dsl.
@@ -31,6 +33,9 @@ type XPackSqlTranslateService struct {
 	pageTimeout             string
 	timeZone                string
 	fieldMultiValueLeniency bool
+
+	cache    TranslateCache
+	cacheTTL time.Duration
 }
 
 // NewXPackSqlTranslateService creates a new XPackSqlTranslateService.
@@ -122,6 +127,24 @@ func (s *XPackSqlTranslateService) SetFieldMultiValueLeniency(leniency bool) *XP
 	return s
 }
 
+// Cache sets a TranslateCache consulted before, and populated after,
+// every request to the `/_sql/translate` endpoint, keyed by a
+// canonical hash of the request. This avoids the translate round-trip
+// for SQL that is sent repeatedly with the same parameters. It is nil
+// (disabled) by default.
+func (s *XPackSqlTranslateService) Cache(cache TranslateCache) *XPackSqlTranslateService {
+	s.cache = cache
+	return s
+}
+
+// CacheTTL specifies how long a cached DSL stays valid. It has no
+// effect unless Cache has been set. A ttl of zero means cached entries
+// never expire on their own.
+func (s *XPackSqlTranslateService) CacheTTL(ttl time.Duration) *XPackSqlTranslateService {
+	s.cacheTTL = ttl
+	return s
+}
+
 // Source allows the user to set the request body manually without using
 // any of the structs and interfaces in Elastic.
 func (s *XPackSqlTranslateService) Source() (interface{}, error) {
@@ -147,13 +170,23 @@ func (s *XPackSqlTranslateService) Source() (interface{}, error) {
 		source["field_multi_value_leniency"] = s.fieldMultiValueLeniency
 	}
 
-	// filter
+	filter, err := s.filterSource()
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		source["filter"] = filter
+	}
+
+	return source, nil
+}
+
+// filterSource returns the source of the filter clause(s) of the
+// request, or nil if none were set. It is shared by Source and the
+// cache key computation in Do.
+func (s *XPackSqlTranslateService) filterSource() (interface{}, error) {
 	if len(s.filterClauses) == 1 {
-		src, err := s.filterClauses[0].Source()
-		if err != nil {
-			return nil, err
-		}
-		source["filter"] = src
+		return s.filterClauses[0].Source()
 	} else if len(s.filterClauses) > 1 {
 		var clauses []interface{}
 		for _, subQuery := range s.filterClauses {
@@ -163,10 +196,9 @@ func (s *XPackSqlTranslateService) Source() (interface{}, error) {
 			}
 			clauses = append(clauses, src)
 		}
-		source["filter"] = clauses
+		return clauses, nil
 	}
-
-	return source, nil
+	return nil, nil
 }
 
 // buildURL builds the URL for the operation.
@@ -196,13 +228,26 @@ func (s *XPackSqlTranslateService) Validate() error {
 	return nil
 }
 
-// Do executes the operation.
+// Do executes the operation. If a Cache was set, it is consulted first
+// and, on a miss, populated with the DSL returned by Elasticsearch.
 func (s *XPackSqlTranslateService) Do(ctx context.Context) (string, error) {
 	// Check pre-conditions
 	if err := s.Validate(); err != nil {
 		return "", err
 	}
 
+	var cacheKey string
+	if s.cache != nil {
+		filter, err := s.filterSource()
+		if err != nil {
+			return "", err
+		}
+		cacheKey = translateCacheKey(s.sql, s.fetchSize, s.timeZone, filter, s.pageTimeout, s.requestTimeout, s.fieldMultiValueLeniency)
+		if dsl, found := s.cache.Get(cacheKey); found {
+			return string(dsl), nil
+		}
+	}
+
 	// Get URL for request
 	path, params, err := s.buildURL()
 	if err != nil {
@@ -227,6 +272,54 @@ func (s *XPackSqlTranslateService) Do(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	if s.cache != nil {
+		s.cache.Set(cacheKey, json.RawMessage(res.Body), s.cacheTTL)
+	}
+
 	// Return operation response
 	return string(res.Body), nil
 }
+
+// TranslateToSearchSource runs Do and applies the query, size and
+// _source clauses of the resulting DSL onto a SearchSource, so it can
+// be merged with additional filters, aggregations or a post_filter
+// before being executed via Client.Search().
+//
+// LIMITATION: Elasticsearch's SQL translate endpoint may also return a
+// top-level "sort" clause, e.g. for an ORDER BY. It is NOT applied
+// here, since SearchSource.SortBy expects typed Sorter values that
+// can't be safely reconstructed from raw DSL. Callers whose SQL
+// includes ORDER BY should call Do directly and apply the "sort" key
+// of the returned DSL themselves.
+func (s *XPackSqlTranslateService) TranslateToSearchSource(ctx context.Context) (*SearchSource, error) {
+	dsl, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed struct {
+		Query  json.RawMessage `json:"query"`
+		Size   *int            `json:"size"`
+		Source json.RawMessage `json:"_source"`
+	}
+	if err := json.Unmarshal([]byte(dsl), &parsed); err != nil {
+		return nil, err
+	}
+
+	source := NewSearchSource()
+	if len(parsed.Query) > 0 {
+		source = source.Query(NewRawStringQuery(string(parsed.Query)))
+	}
+	if parsed.Size != nil {
+		source = source.Size(*parsed.Size)
+	}
+	// _source can also be an object (e.g. {"includes": [...]}) rather
+	// than a plain boolean; only the boolean form is applied here.
+	if len(parsed.Source) > 0 {
+		var fetchSource bool
+		if err := json.Unmarshal(parsed.Source, &fetchSource); err == nil {
+			source = source.FetchSource(fetchSource)
+		}
+	}
+	return source, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// TranslateCache is a pluggable cache in front of
+// XPackSqlTranslateService.Do, keyed by a canonical hash of the
+// translate request. Implementations must be safe for concurrent use.
+// Use LRUTranslateCache for a ready-made in-memory implementation, or
+// wire up your own, e.g. backed by Redis.
+type TranslateCache interface {
+	// Get returns the cached DSL for key and whether it was found.
+	Get(key string) (json.RawMessage, bool)
+	// Set stores dsl under key. A ttl of zero means the entry never
+	// expires on its own.
+	Set(key string, dsl json.RawMessage, ttl time.Duration)
+}
+
+// translateCacheKey returns a canonical cache key for a translate
+// request, derived from the fields that affect the resulting DSL.
+func translateCacheKey(sql string, fetchSize int, timeZone string, filter interface{}, pageTimeout, requestTimeout string, fieldMultiValueLeniency bool) string {
+	key := struct {
+		SQL                     string      `json:"sql"`
+		FetchSize               int         `json:"fetch_size"`
+		TimeZone                string      `json:"time_zone"`
+		Filter                  interface{} `json:"filter,omitempty"`
+		PageTimeout             string      `json:"page_timeout"`
+		RequestTimeout          string      `json:"request_timeout"`
+		FieldMultiValueLeniency bool        `json:"field_multi_value_leniency"`
+	}{
+		SQL:                     sql,
+		FetchSize:               fetchSize,
+		TimeZone:                timeZone,
+		Filter:                  filter,
+		PageTimeout:             pageTimeout,
+		RequestTimeout:          requestTimeout,
+		FieldMultiValueLeniency: fieldMultiValueLeniency,
+	}
+	// All fields are either basic types or built from Query.Source,
+	// both of which always marshal cleanly.
+	data, _ := json.Marshal(key)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LRUTranslateCache is an in-memory TranslateCache that evicts the
+// least recently used entry once it holds more than capacity entries.
+// The zero value is not usable; create one with NewLRUTranslateCache.
+type LRUTranslateCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruTranslateCacheEntry struct {
+	key       string
+	dsl       json.RawMessage
+	expiresAt time.Time
+}
+
+// NewLRUTranslateCache creates an LRUTranslateCache holding at most
+// capacity entries. A non-positive capacity defaults to 128.
+func NewLRUTranslateCache(capacity int) *LRUTranslateCache {
+	if capacity <= 0 {
+		capacity = 128
+	}
+	return &LRUTranslateCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements TranslateCache.
+func (c *LRUTranslateCache) Get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruTranslateCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.dsl, true
+}
+
+// Set implements TranslateCache.
+func (c *LRUTranslateCache) Set(key string, dsl json.RawMessage, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		entry := el.Value.(*lruTranslateCacheEntry)
+		entry.dsl, entry.expiresAt = dsl, expiresAt
+		return
+	}
+
+	el := c.ll.PushFront(&lruTranslateCacheEntry{key: key, dsl: dsl, expiresAt: expiresAt})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruTranslateCacheEntry).key)
+		}
+	}
+}
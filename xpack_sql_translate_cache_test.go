@@ -0,0 +1,78 @@
+// Copyright 2012-2018 Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLRUTranslateCacheGetSet(t *testing.T) {
+	c := NewLRUTranslateCache(2)
+
+	if _, found := c.Get("missing"); found {
+		t.Fatal("expected a miss for an unset key")
+	}
+
+	dsl := json.RawMessage(`{"query":{"match_all":{}}}`)
+	c.Set("a", dsl, 0)
+
+	got, found := c.Get("a")
+	if !found {
+		t.Fatal("expected a hit after Set")
+	}
+	if string(got) != string(dsl) {
+		t.Fatalf("got %s, want %s", got, dsl)
+	}
+}
+
+func TestLRUTranslateCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUTranslateCache(2)
+
+	c.Set("a", json.RawMessage(`"a"`), 0)
+	c.Set("b", json.RawMessage(`"b"`), 0)
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, found := c.Get("a"); !found {
+		t.Fatal("expected a to be present")
+	}
+
+	c.Set("c", json.RawMessage(`"c"`), 0)
+
+	if _, found := c.Get("b"); found {
+		t.Fatal("expected b to have been evicted")
+	}
+	if _, found := c.Get("a"); !found {
+		t.Fatal("expected a to still be present")
+	}
+	if _, found := c.Get("c"); !found {
+		t.Fatal("expected c to be present")
+	}
+}
+
+func TestLRUTranslateCacheTTLExpiry(t *testing.T) {
+	c := NewLRUTranslateCache(2)
+
+	c.Set("a", json.RawMessage(`"a"`), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, found := c.Get("a"); found {
+		t.Fatal("expected entry to have expired")
+	}
+}
+
+func TestTranslateCacheKeyIsStableAndDistinguishesInputs(t *testing.T) {
+	k1 := translateCacheKey("SELECT 1", 0, "", nil, "", "", false)
+	k2 := translateCacheKey("SELECT 1", 0, "", nil, "", "", false)
+	if k1 != k2 {
+		t.Fatal("expected identical inputs to produce the same cache key")
+	}
+
+	k3 := translateCacheKey("SELECT 2", 0, "", nil, "", "", false)
+	if k1 == k3 {
+		t.Fatal("expected different sql to produce different cache keys")
+	}
+}